@@ -0,0 +1,160 @@
+package gologs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// PublishOptions customizes a single *Sync publish.
+type PublishOptions struct {
+	// Mandatory asks the broker to return the message instead of silently
+	// dropping it if it can't be routed to any queue.
+	Mandatory bool
+	// Timeout bounds how long the call waits for a broker confirm. Defaults to 5s.
+	Timeout time.Duration
+	// Headers are merged into the AMQP message headers.
+	Headers amqp.Table
+	// Priority sets the message priority (0-9); only honored by priority queues.
+	Priority uint8
+}
+
+// WithPublisherConfirms puts the channel into confirm mode (channel.Confirm(false))
+// so every publish can wait for its own broker ack/nack instead of firing and
+// forgetting. Confirms are re-enabled automatically after every reconnect.
+func WithPublisherConfirms() Option {
+	return func(c *LogClient) {
+		c.confirms = true
+	}
+}
+
+// enableConfirms puts ch into confirm mode. Once in that mode, every publish
+// on ch made via PublishWithDeferredConfirmWithContext (which
+// publishWithConfirm/PublishAuditLogSync use) returns its own
+// *amqp.DeferredConfirmation, tracked by the broker's delivery tag rather
+// than a shared notification channel - so waiting on one publish's result
+// can never consume another's.
+func (c *LogClient) enableConfirms(ch *amqp.Channel) error {
+	if err := ch.Confirm(false); err != nil {
+		return fmt.Errorf("failed to put channel into confirm mode: %w", err)
+	}
+	return nil
+}
+
+// waitConfirm blocks on confirmation until it resolves or ctx is done, and
+// translates the result into an error. If the channel disconnects while a
+// confirmation is outstanding, amqp091-go resolves every pending
+// confirmation as nacked; activeChannel lets us report that as
+// ErrDisconnected instead of a misleading "broker nacked".
+func (c *LogClient) waitConfirm(ctx context.Context, confirmation *amqp.DeferredConfirmation) error {
+	ok, err := confirmation.WaitContext(ctx)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if _, chErr := c.activeChannel(); chErr != nil {
+			return ErrDisconnected
+		}
+		return errors.New("gologs: broker nacked message")
+	}
+	return nil
+}
+
+// publishWithConfirm publishes publishing on ch and, if the client was
+// created with WithPublisherConfirms, waits up to 5s for this specific
+// message's broker confirmation before returning.
+func (c *LogClient) publishWithConfirm(ch *amqp.Channel, exchange, routingKey string, mandatory bool, publishing amqp.Publishing) error {
+	if !c.confirms {
+		return ch.Publish(exchange, routingKey, mandatory, false, publishing)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(ctx, exchange, routingKey, mandatory, false, publishing)
+	if err != nil {
+		return err
+	}
+	if confirmation == nil {
+		// ch wasn't actually in confirm mode; shouldn't happen once
+		// enableConfirms has run, but don't block on a nil confirmation.
+		return nil
+	}
+
+	return c.waitConfirm(ctx, confirmation)
+}
+
+// PublishAuditLogSync sends an audit log as a persistent message and blocks
+// until the broker acks it, nacks it, ctx is done, or opts.Timeout elapses
+// (default 5s). The client must have been created with WithPublisherConfirms.
+// Unlike PublishAuditLog, which is best-effort, this is meant for audit
+// events that must not be silently lost on a broker restart.
+func (c *LogClient) PublishAuditLogSync(ctx context.Context, entry AuditLog, opts *PublishOptions) error {
+	if !c.confirms {
+		return errors.New("gologs: publisher confirms are not enabled; create the client with WithPublisherConfirms")
+	}
+	if opts == nil {
+		opts = &PublishOptions{}
+	}
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+
+	entry.ActionTime = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+
+	publishing := amqp.Publishing{
+		ContentType:  "application/json",
+		DeliveryMode: amqp.Persistent,
+		MessageId:    fmt.Sprintf("audit-%d", time.Now().UnixNano()),
+		Timestamp:    time.Now(),
+		Priority:     opts.Priority,
+		Headers:      opts.Headers,
+		Body:         payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	confirmation, err := ch.PublishWithDeferredConfirmWithContext(
+		ctx,
+		"",             // exchange
+		AuditTopicName, // routing key
+		opts.Mandatory, // mandatory
+		false,          // immediate
+		publishing,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to publish audit message %s: %w", publishing.MessageId, err)
+	}
+	if confirmation == nil {
+		return nil
+	}
+
+	if err := c.waitConfirm(ctx, confirmation); err != nil {
+		return fmt.Errorf("audit message %s: %w", publishing.MessageId, err)
+	}
+	return nil
+}
+
+// PublishAuditLogSync publishes via the global client. Prefer NewClient for new code.
+func PublishAuditLogSync(ctx context.Context, entry AuditLog, opts *PublishOptions) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishAuditLogSync(ctx, entry, opts)
+}
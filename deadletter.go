@@ -0,0 +1,311 @@
+package gologs
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const (
+	// AuditDLXExchange and ActivityDLXExchange are fanout exchanges that
+	// main queues are configured to dead-letter into (via the
+	// x-dead-letter-exchange queue argument) once a message exceeds its
+	// retry budget.
+	AuditDLXExchange    = "audit_logs.dlx"
+	ActivityDLXExchange = "activity_logs.dlx"
+
+	// AuditDeadLetterQueue and ActivityDeadLetterQueue hold messages that
+	// were dead-lettered from the corresponding main queue, for inspection
+	// or replay via ConsumeAuditDeadLetters/ConsumeActivityDeadLetters.
+	AuditDeadLetterQueue    = "DLX.audit_logs"
+	ActivityDeadLetterQueue = "DLX.activity_logs"
+
+	// RetryCountHeader tracks how many times a message has been retried via
+	// a Decision returned as Retry.
+	RetryCountHeader = "x-retries"
+
+	// DefaultMaxRetries is how many times a message may be retried before
+	// it is dropped without requeue, landing in its dead-letter queue.
+	DefaultMaxRetries = 5
+)
+
+// Decision tells gologs what to do with a message once a handler is done
+// with it.
+type Decision int
+
+const (
+	// Ack acknowledges the message; it is removed from the queue.
+	Ack Decision = iota
+	// Retry republishes the message onto its original queue with its
+	// x-retries header incremented, up to the client's MaxRetries. Once
+	// exceeded, the message is dropped without requeue instead, so it lands
+	// in the paired dead-letter queue.
+	Retry
+	// Drop nacks the message without requeue, sending it straight to its
+	// dead-letter queue.
+	Drop
+)
+
+// WithMaxRetries overrides how many times a Retry decision will republish a
+// message before it is dead-lettered instead. Defaults to DefaultMaxRetries.
+func WithMaxRetries(n int) Option {
+	return func(c *LogClient) {
+		c.maxRetries = n
+	}
+}
+
+// dlxExchangeFor returns the fanout exchange a main queue dead-letters into.
+func dlxExchangeFor(topicName string) string {
+	switch topicName {
+	case ActivityTopicName:
+		return ActivityDLXExchange
+	default:
+		return AuditDLXExchange
+	}
+}
+
+// deadLetterQueueFor returns the queue that holds topicName's dead letters.
+func deadLetterQueueFor(topicName string) string {
+	switch topicName {
+	case ActivityTopicName:
+		return ActivityDeadLetterQueue
+	default:
+		return AuditDeadLetterQueue
+	}
+}
+
+// declareDeadLetterTopology declares the fanout exchange and queue that
+// topicName's main queue dead-letters into, and binds them together.
+func declareDeadLetterTopology(ch *amqp.Channel, topicName string) error {
+	exchange := dlxExchangeFor(topicName)
+	queue := deadLetterQueueFor(topicName)
+
+	if err := ch.ExchangeDeclare(
+		exchange, // name
+		"fanout", // kind
+		true,     // durable
+		false,    // auto-deleted
+		false,    // internal
+		false,    // no-wait
+		nil,      // arguments
+	); err != nil {
+		return fmt.Errorf("failed to declare dead-letter exchange %q: %w", exchange, err)
+	}
+
+	if _, err := ch.QueueDeclare(
+		queue, // name
+		true,  // durable
+		false, // delete when unused
+		false, // exclusive
+		false, // no-wait
+		nil,   // arguments
+	); err != nil {
+		return fmt.Errorf("failed to declare dead-letter queue %q: %w", queue, err)
+	}
+
+	if err := ch.QueueBind(
+		queue,    // queue name
+		"",       // routing key - ignored by fanout exchanges
+		exchange, // exchange
+		false,    // no-wait
+		nil,      // arguments
+	); err != nil {
+		return fmt.Errorf("failed to bind dead-letter queue %q to %q: %w", queue, exchange, err)
+	}
+
+	return nil
+}
+
+// resolveDecision applies handler's Decision to msg: Ack acknowledges it,
+// Drop nacks it without requeue, and Retry republishes it onto queueName
+// with its x-retries header incremented, unless that would exceed
+// maxRetries, in which case it is dropped like Drop.
+func resolveDecision(ch *amqp.Channel, msg amqp.Delivery, queueName string, maxRetries int, decision Decision) {
+	switch decision {
+	case Ack:
+		if err := msg.Ack(false); err != nil {
+			log.Printf("gologs: failed to acknowledge message on %q: %v", queueName, err)
+		}
+	case Drop:
+		if err := msg.Nack(false, false); err != nil {
+			log.Printf("gologs: failed to drop message on %q: %v", queueName, err)
+		}
+	case Retry:
+		retries := retryCount(msg.Headers) + 1
+		if retries > maxRetries {
+			log.Printf("gologs: message on %q exceeded max retries (%d), dead-lettering", queueName, maxRetries)
+			if err := msg.Nack(false, false); err != nil {
+				log.Printf("gologs: failed to dead-letter message on %q: %v", queueName, err)
+			}
+			return
+		}
+
+		headers := amqp.Table{}
+		for k, v := range msg.Headers {
+			headers[k] = v
+		}
+		headers[RetryCountHeader] = int32(retries)
+
+		if err := ch.Publish(
+			"",        // exchange - default exchange routes by queue name
+			queueName, // routing key
+			false,     // mandatory
+			false,     // immediate
+			amqp.Publishing{
+				ContentType:  msg.ContentType,
+				DeliveryMode: msg.DeliveryMode,
+				Headers:      headers,
+				Body:         msg.Body,
+			},
+		); err != nil {
+			log.Printf("gologs: failed to republish message on %q for retry %d: %v", queueName, retries, err)
+			return
+		}
+
+		if err := msg.Ack(false); err != nil {
+			log.Printf("gologs: failed to acknowledge original message on %q after retry republish: %v", queueName, err)
+		}
+	}
+}
+
+// retryCount reads the current x-retries header from headers, defaulting to 0.
+func retryCount(headers amqp.Table) int {
+	v, ok := headers[RetryCountHeader]
+	if !ok {
+		return 0
+	}
+	switch n := v.(type) {
+	case int32:
+		return int(n)
+	case int64:
+		return int(n)
+	case int:
+		return n
+	default:
+		return 0
+	}
+}
+
+// ConsumeAuditDeadLetters subscribes to the audit dead-letter queue so
+// operators can inspect or replay poisoned entries. Ack removes the entry,
+// Retry republishes it onto the main audit_logs queue for reprocessing, and
+// Drop discards it permanently.
+func (c *LogClient) ConsumeAuditDeadLetters(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	return c.consumeDeadLetters(AuditTopicName, consumerName, prefetchCount, func(msg amqp.Delivery, decide func(Decision)) {
+		var auditLog AuditLog
+		if err := json.Unmarshal(msg.Body, &auditLog); err != nil {
+			log.Printf("Error unmarshaling dead-lettered audit message: %v", err)
+			return
+		}
+		handler(auditLog, decide)
+	})
+}
+
+// ConsumeActivityDeadLetters subscribes to the activity dead-letter queue.
+// See ConsumeAuditDeadLetters for the Decision semantics.
+func (c *LogClient) ConsumeActivityDeadLetters(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	return c.consumeDeadLetters(ActivityTopicName, consumerName, prefetchCount, func(msg amqp.Delivery, decide func(Decision)) {
+		var activityLog ActivityLog
+		if err := json.Unmarshal(msg.Body, &activityLog); err != nil {
+			log.Printf("Error unmarshaling dead-lettered activity message: %v", err)
+			return
+		}
+		handler(activityLog, decide)
+	})
+}
+
+// ConsumeAuditDeadLetters consumes from the global client. Prefer NewClient for new code.
+func ConsumeAuditDeadLetters(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeAuditDeadLetters(consumerName, handler, prefetchCount)
+}
+
+// ConsumeActivityDeadLetters consumes from the global client. Prefer NewClient for new code.
+func ConsumeActivityDeadLetters(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeActivityDeadLetters(consumerName, handler, prefetchCount)
+}
+
+// consumeDeadLetters is the shared plumbing behind ConsumeAuditDeadLetters
+// and ConsumeActivityDeadLetters: it consumes the dead-letter queue paired
+// with topicName, and for a Retry decision republishes onto topicName
+// itself rather than the dead-letter queue.
+func (c *LogClient) consumeDeadLetters(topicName string, consumerName *string, prefetchCount *int, unmarshal func(amqp.Delivery, func(Decision))) error {
+	if consumerName == nil {
+		defaultName := "default_" + topicName + "_dlx_consumer"
+		consumerName = &defaultName
+	}
+
+	effectivePrefetchCount := 50
+	if prefetchCount != nil {
+		effectivePrefetchCount = *prefetchCount
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	queueName := deadLetterQueueFor(topicName)
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,     // queue
+		*consumerName, // consumer name
+		false,         // auto-ack
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register a dead-letter consumer on %q: %w", queueName, err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			msg := msg
+			unmarshal(msg, func(decision Decision) {
+				switch decision {
+				case Retry:
+					// Replay onto the original queue rather than the dead-letter
+					// queue itself; there's no retry budget to enforce here since
+					// an operator asked for the replay explicitly.
+					if err := ch.Publish(
+						"",        // exchange - default exchange routes by queue name
+						topicName, // routing key
+						false,     // mandatory
+						false,     // immediate
+						amqp.Publishing{
+							ContentType:  msg.ContentType,
+							DeliveryMode: msg.DeliveryMode,
+							Body:         msg.Body,
+						},
+					); err != nil {
+						log.Printf("gologs: failed to replay dead-lettered message onto %q: %v", topicName, err)
+						return
+					}
+					if err := msg.Ack(false); err != nil {
+						log.Printf("gologs: failed to acknowledge replayed dead-letter message: %v", err)
+					}
+				default:
+					resolveDecision(ch, msg, queueName, 0, decision)
+				}
+			})
+		}
+	}()
+
+	log.Printf("Dead-letter consumer %s is waiting for messages on %q. To exit press CTRL+C", *consumerName, queueName)
+	return nil
+}
@@ -6,15 +6,24 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"sync"
 	"time"
 
 	"github.com/joho/godotenv"
 	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel/trace"
 )
 
 const (
 	AuditTopicName    = "audit_logs"
 	ActivityTopicName = "activity_logs"
+
+	// AuditTopicExchange and ActivityTopicExchange back the topic-routing
+	// variants of the audit/activity APIs, letting consumers subscribe with
+	// binding patterns (e.g. "audit.users.*", "audit.#") instead of reading
+	// the whole direct queue.
+	AuditTopicExchange    = "audit_logs.topic"
+	ActivityTopicExchange = "activity_logs.topic"
 )
 
 // AuditLog represents a single audit log entry.
@@ -41,15 +50,59 @@ type ActivityLog struct {
 	Remarks      string    `json:"remarks,omitempty"`
 }
 
-// LogClient manages the connection and channel for RabbitMQ.
+// LogClient manages a RabbitMQ connection and channel for a single queue
+// (AuditTopicName or ActivityTopicName) and implements LogPublisher and
+// LogConsumer for it. It is the RabbitMQ backend behind NewClient(RabbitMQ(...)),
+// and is also usable directly for backward compatibility with the
+// InitAuditLogClient/InitActivityLogClient global-client API.
 type LogClient struct {
 	connection *amqp.Connection
 	channel    *amqp.Channel
+
+	url       string
+	topicName string
+	reconnect *ReconnectOptions
+
+	mu        sync.Mutex
+	connected bool
+	consumers []registeredConsumer
+	pending   chan pendingPublish
+	closing   chan struct{}
+
+	// confirms enables publisher confirms (WithPublisherConfirms). Each
+	// publish then tracks its own broker confirmation via
+	// PublishWithDeferredConfirmWithContext rather than a shared channel.
+	confirms bool
+
+	maxRetries int
+
+	// tracerProvider backs the *Context publish/consume variants. Nil means
+	// fall back to otel.GetTracerProvider().
+	tracerProvider trace.TracerProvider
 }
 
-// Global instance of LogClient
+// Global instance of LogClient, used by the legacy InitAuditLogClient/
+// InitActivityLogClient/PublishAuditLog/... package-level API. New code
+// should prefer NewClient/NewLogClient, which don't rely on global state.
 var logClient *LogClient
 
+// ErrGlobalClientNotInitialized is returned by the package-level
+// PublishAuditLog/ConsumeAuditLogs/... family when called before
+// InitAuditLogClient or InitActivityLogClient.
+var ErrGlobalClientNotInitialized = errors.New("gologs: global client not initialized, call InitAuditLogClient/InitActivityLogClient first")
+
+// globalClient returns the package-level logClient, or
+// ErrGlobalClientNotInitialized if InitAuditLogClient/InitActivityLogClient
+// hasn't been called yet. Every package-level wrapper function goes through
+// this instead of dereferencing logClient directly, so calling them before
+// Init returns a clear error rather than panicking on a nil pointer.
+func globalClient() (*LogClient, error) {
+	if logClient == nil {
+		return nil, ErrGlobalClientNotInitialized
+	}
+	return logClient, nil
+}
+
 // InitAuditLogClient initializes the global AuditLogClient.
 func InitAuditLogClient() error {
 	return initLogClient(AuditTopicName)
@@ -60,18 +113,35 @@ func InitActivityLogClient() error {
 	return initLogClient(ActivityTopicName)
 }
 
-// initLogClient initializes the log client and declares the queue.
+// initLogClient initializes the global log client and declares the queue.
+// It is kept around for InitAuditLogClient/InitActivityLogClient; new code
+// should prefer NewLogClient, which also supports WithReconnect.
 func initLogClient(topicName string) error {
-	if err := godotenv.Load(); err != nil {
-		log.Println("Warning: Could not load .env file, using environment variables from the host")
+	c, err := NewLogClient(topicName)
+	if err != nil {
+		return err
 	}
+	logClient = c
+	return nil
+}
+
+// dial opens the connection and channel, reloads the RabbitMQ URL on the
+// first call, and (re)declares the client's queue. It is used both for the
+// initial connect and for every reconnect attempt.
+func (c *LogClient) dial() error {
+	if c.url == "" {
+		if err := godotenv.Load(); err != nil {
+			log.Println("Warning: Could not load .env file, using environment variables from the host")
+		}
 
-	rabbitMQURL := os.Getenv("RABBITMQ_URL")
-	if rabbitMQURL == "" {
-		return errors.New("RABBITMQ_URL must be set in the environment variables or .env file")
+		rabbitMQURL := os.Getenv("RABBITMQ_URL")
+		if rabbitMQURL == "" {
+			return errors.New("RABBITMQ_URL must be set in the environment variables or .env file")
+		}
+		c.url = rabbitMQURL
 	}
 
-	conn, err := amqp.Dial(rabbitMQURL)
+	conn, err := amqp.Dial(c.url)
 	if err != nil {
 		return fmt.Errorf("failed to connect to RabbitMQ: %w", err)
 	}
@@ -82,77 +152,371 @@ func initLogClient(topicName string) error {
 		return fmt.Errorf("failed to open a channel: %w", err)
 	}
 
+	if err := declareDeadLetterTopology(ch, c.topicName); err != nil {
+		_ = ch.Close()
+		_ = conn.Close()
+		return err
+	}
+
+	if err := declareTopicExchanges(ch); err != nil {
+		_ = ch.Close()
+		_ = conn.Close()
+		return err
+	}
+
 	if _, err := ch.QueueDeclare(
-		topicName, // name
-		true,      // durable
-		false,     // delete when unused
-		false,     // exclusive
-		false,     // no-wait
-		nil,       // arguments
+		c.topicName, // name
+		true,        // durable
+		false,       // delete when unused
+		false,       // exclusive
+		false,       // no-wait
+		amqp.Table{"x-dead-letter-exchange": dlxExchangeFor(c.topicName)}, // arguments
 	); err != nil {
 		_ = ch.Close()
 		_ = conn.Close()
 		return fmt.Errorf("failed to declare a queue: %w", err)
 	}
 
-	logClient = &LogClient{
-		connection: conn,
-		channel:    ch,
+	if c.confirms {
+		if err := c.enableConfirms(ch); err != nil {
+			_ = ch.Close()
+			_ = conn.Close()
+			return err
+		}
 	}
 
+	c.mu.Lock()
+	c.connection = conn
+	c.channel = ch
+	c.connected = true
+	c.mu.Unlock()
+
 	return nil
 }
 
-// PublishAuditLog sends an audit log to the RabbitMQ queue.
-func PublishAuditLog(log AuditLog) error {
-	log.ActionTime = time.Now()
-	payload, err := json.Marshal(log)
+// PublishAuditLog sends an audit log to the RabbitMQ queue. If the client is
+// reconnecting, it returns ErrDisconnected unless a reconnect buffer was
+// configured via WithReconnect, in which case the publish is queued for
+// replay once the channel is re-established.
+func (c *LogClient) PublishAuditLog(entry AuditLog) error {
+	entry.ActionTime = time.Now()
+	payload, err := json.Marshal(entry)
 	if err != nil {
 		return fmt.Errorf("failed to marshal audit log: %w", err)
 	}
 
-	if err := logClient.channel.Publish(
-		"",             // exchange
-		AuditTopicName, // routing key
-		false,          // mandatory
-		false,          // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        payload,
-		},
-	); err != nil {
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish("", AuditTopicName, publishing) {
+			return nil
+		}
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, "", AuditTopicName, false, publishing); err != nil {
 		return fmt.Errorf("failed to publish audit message: %w", err)
 	}
 
 	return nil
 }
 
-// PublishActivityLog sends an activity log to the RabbitMQ queue.
-func PublishActivityLog(log ActivityLog) error {
-	log.ActivityTime = time.Now()
-	payload, err := json.Marshal(log)
+// PublishActivityLog sends an activity log to the RabbitMQ queue. See
+// PublishAuditLog for the disconnected/buffering behavior.
+func (c *LogClient) PublishActivityLog(entry ActivityLog) error {
+	entry.ActivityTime = time.Now()
+	payload, err := json.Marshal(entry)
 	if err != nil {
 		return fmt.Errorf("failed to marshal activity log: %w", err)
 	}
 
-	if err := logClient.channel.Publish(
-		"",                // exchange
-		ActivityTopicName, // routing key
-		false,             // mandatory
-		false,             // immediate
-		amqp.Publishing{
-			ContentType: "application/json",
-			Body:        payload,
-		},
-	); err != nil {
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish("", ActivityTopicName, publishing) {
+			return nil
+		}
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, "", ActivityTopicName, false, publishing); err != nil {
 		return fmt.Errorf("failed to publish activity message: %w", err)
 	}
 
 	return nil
 }
 
+// PublishAuditLogWithKey sends an audit log to the audit topic exchange using
+// routingKey, which should encode module/severity (e.g. "audit.users.delete")
+// so that consumers can filter with binding patterns. The existing direct
+// queue used by PublishAuditLog/ConsumeAuditLogs is untouched.
+func (c *LogClient) PublishAuditLogWithKey(entry AuditLog, routingKey string) error {
+	entry.ActionTime = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish(AuditTopicExchange, routingKey, publishing) {
+			return nil
+		}
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, AuditTopicExchange, routingKey, false, publishing); err != nil {
+		return fmt.Errorf("failed to publish audit message with routing key %q: %w", routingKey, err)
+	}
+
+	return nil
+}
+
+// PublishActivityLogWithKey sends an activity log to the activity topic
+// exchange using routingKey. See PublishAuditLogWithKey for the routing key
+// convention.
+func (c *LogClient) PublishActivityLogWithKey(entry ActivityLog, routingKey string) error {
+	entry.ActivityTime = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity log: %w", err)
+	}
+
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish(ActivityTopicExchange, routingKey, publishing) {
+			return nil
+		}
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, ActivityTopicExchange, routingKey, false, publishing); err != nil {
+		return fmt.Errorf("failed to publish activity message with routing key %q: %w", routingKey, err)
+	}
+
+	return nil
+}
+
+// declareTopicExchanges declares the durable topic exchanges backing the
+// WithKey/WithTopics APIs. It runs once per dial() (initial connect and
+// every reconnect) rather than on every publish, since ExchangeDeclare is a
+// synchronous broker round trip.
+func declareTopicExchanges(ch *amqp.Channel) error {
+	for _, name := range []string{AuditTopicExchange, ActivityTopicExchange} {
+		if err := ch.ExchangeDeclare(
+			name,    // name
+			"topic", // kind
+			true,    // durable
+			false,   // auto-deleted
+			false,   // internal
+			false,   // no-wait
+			nil,     // arguments
+		); err != nil {
+			return fmt.Errorf("failed to declare topic exchange %q: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// topicNameForExchange maps a topic exchange back to the main topic name it
+// routes for, so bindTopicQueue can dead-letter into the same DLX a direct
+// queue consumer on that topic would use.
+func topicNameForExchange(exchange string) string {
+	if exchange == ActivityTopicExchange {
+		return ActivityTopicName
+	}
+	return AuditTopicName
+}
+
+// bindTopicQueue declares an ephemeral, auto-delete queue bound to exchange
+// for each of the given binding patterns, and returns the queue name. The
+// queue is dead-lettered into the same DLX as exchange's direct queue, so a
+// handler returning Drop (or exceeding MaxRetries on Retry) lands the
+// message in DLX.audit_logs/DLX.activity_logs just like the direct-queue
+// consumers do.
+func (c *LogClient) bindTopicQueue(exchange string, patterns []string) (string, error) {
+	ch, err := c.activeChannel()
+	if err != nil {
+		return "", err
+	}
+
+	q, err := ch.QueueDeclare(
+		"",    // name - let the server generate a unique name
+		false, // durable
+		true,  // delete when unused
+		true,  // exclusive
+		false, // no-wait
+		amqp.Table{"x-dead-letter-exchange": dlxExchangeFor(topicNameForExchange(exchange))}, // arguments
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to declare an ephemeral queue for %q: %w", exchange, err)
+	}
+
+	for _, pattern := range patterns {
+		if err := ch.QueueBind(
+			q.Name,   // queue name
+			pattern,  // routing key
+			exchange, // exchange
+			false,    // no-wait
+			nil,      // arguments
+		); err != nil {
+			return "", fmt.Errorf("failed to bind queue to pattern %q on %q: %w", pattern, exchange, err)
+		}
+	}
+
+	return q.Name, nil
+}
+
+// ConsumeAuditLogsWithTopics subscribes to the audit topic exchange through
+// an ephemeral auto-delete queue bound with the given patterns (e.g.
+// "audit.users.*", "audit.#") and delivers matching messages to handler.
+func (c *LogClient) ConsumeAuditLogsWithTopics(topics []string, consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	if consumerName == nil {
+		defaultName := "default_audit_topic_consumer"
+		consumerName = &defaultName
+	}
+
+	var effectivePrefetchCount int
+	if prefetchCount != nil {
+		effectivePrefetchCount = *prefetchCount
+	} else {
+		effectivePrefetchCount = 50 // Default value
+	}
+
+	queueName, err := c.bindTopicQueue(AuditTopicExchange, topics)
+	if err != nil {
+		return err
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,     // queue
+		*consumerName, // consumer name
+		false,         // auto-ack
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register an audit topic consumer: %w", err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			var auditLog AuditLog
+			if err := json.Unmarshal(msg.Body, &auditLog); err != nil {
+				log.Printf("Error unmarshaling audit message: %v", err)
+				continue
+			}
+
+			handler(auditLog, func(decision Decision) {
+				resolveDecision(ch, msg, queueName, c.maxRetries, decision)
+			})
+		}
+	}()
+
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeAuditLogsWithTopics(topics, consumerName, handler, prefetchCount)
+	})
+
+	log.Printf("Audit topic consumer %s is waiting for messages on patterns %v. To exit press CTRL+C", *consumerName, topics)
+	return nil
+}
+
+// ConsumeActivityLogsWithTopics subscribes to the activity topic exchange
+// through an ephemeral auto-delete queue bound with the given patterns. See
+// ConsumeAuditLogsWithTopics for the binding semantics.
+func (c *LogClient) ConsumeActivityLogsWithTopics(topics []string, consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	if consumerName == nil {
+		defaultName := "default_activity_topic_consumer"
+		consumerName = &defaultName
+	}
+
+	var effectivePrefetchCount int
+	if prefetchCount != nil {
+		effectivePrefetchCount = *prefetchCount
+	} else {
+		effectivePrefetchCount = 50 // Default value
+	}
+
+	queueName, err := c.bindTopicQueue(ActivityTopicExchange, topics)
+	if err != nil {
+		return err
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		queueName,     // queue
+		*consumerName, // consumer name
+		false,         // auto-ack
+		false,         // exclusive
+		false,         // no-local
+		false,         // no-wait
+		nil,           // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register an activity topic consumer: %w", err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			var activityLog ActivityLog
+			if err := json.Unmarshal(msg.Body, &activityLog); err != nil {
+				log.Printf("Error unmarshaling activity message: %v", err)
+				continue
+			}
+
+			handler(activityLog, func(decision Decision) {
+				resolveDecision(ch, msg, queueName, c.maxRetries, decision)
+			})
+		}
+	}()
+
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeActivityLogsWithTopics(topics, consumerName, handler, prefetchCount)
+	})
+
+	log.Printf("Activity topic consumer %s is waiting for messages on patterns %v. To exit press CTRL+C", *consumerName, topics)
+	return nil
+}
+
 // ConsumeAuditLogs starts consuming audit logs from the queue.
-func ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(bool)), prefetchCount *int) error {
+func (c *LogClient) ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
 	if consumerName == nil {
 		defaultName := "default_audit_consumer"
 		consumerName = &defaultName
@@ -165,11 +529,16 @@ func ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(bool)),
 		effectivePrefetchCount = 50 // Default value
 	}
 
-	if err := logClient.channel.Qos(effectivePrefetchCount, 0, false); err != nil {
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
 		return fmt.Errorf("failed to set QoS: %w", err)
 	}
 
-	msgs, err := logClient.channel.Consume(
+	msgs, err := ch.Consume(
 		AuditTopicName, // queue
 		*consumerName,  // consumer name
 		false,          // auto-ack
@@ -190,26 +559,22 @@ func ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(bool)),
 				continue
 			}
 
-			handler(auditLog, func(ack bool) {
-				if ack {
-					if err := msg.Ack(false); err != nil {
-						log.Printf("Failed to acknowledge audit message: %v", err)
-					}
-				} else {
-					if err := msg.Nack(false, true); err != nil {
-						log.Printf("Failed to nack audit message: %v", err)
-					}
-				}
+			handler(auditLog, func(decision Decision) {
+				resolveDecision(ch, msg, AuditTopicName, c.maxRetries, decision)
 			})
 		}
 	}()
 
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeAuditLogs(consumerName, handler, prefetchCount)
+	})
+
 	log.Printf("Audit consumer %s is waiting for messages. To exit press CTRL+C", *consumerName)
 	return nil
 }
 
 // ConsumeActivityLogs starts consuming activity logs from the queue.
-func ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(bool)), prefetchCount *int) error {
+func (c *LogClient) ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
 	if consumerName == nil {
 		defaultName := "default_activity_consumer"
 		consumerName = &defaultName
@@ -222,11 +587,16 @@ func ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(bo
 		effectivePrefetchCount = 50 // Default value
 	}
 
-	if err := logClient.channel.Qos(effectivePrefetchCount, 0, false); err != nil {
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
 		return fmt.Errorf("failed to set QoS: %w", err)
 	}
 
-	msgs, err := logClient.channel.Consume(
+	msgs, err := ch.Consume(
 		ActivityTopicName, // queue
 		*consumerName,     // consumer name
 		false,             // auto-ack
@@ -247,35 +617,121 @@ func ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(bo
 				continue
 			}
 
-			handler(activityLog, func(ack bool) {
-				if ack {
-					if err := msg.Ack(false); err != nil {
-						log.Printf("Failed to acknowledge activity message: %v", err)
-					}
-				} else {
-					if err := msg.Nack(false, true); err != nil {
-						log.Printf("Failed to nack activity message: %v", err)
-					}
-				}
+			handler(activityLog, func(decision Decision) {
+				resolveDecision(ch, msg, ActivityTopicName, c.maxRetries, decision)
 			})
 		}
 	}()
 
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeActivityLogs(consumerName, handler, prefetchCount)
+	})
+
 	log.Printf("Activity consumer %s is waiting for messages. To exit press CTRL+C", *consumerName)
 	return nil
 }
 
-// Close closes the channel and connection of the LogClient.
-func Close() {
-	if logClient.channel != nil {
-		_ = logClient.channel.Close()
+// Close stops the reconnect supervisor (if any) and closes the channel and
+// connection of the client.
+func (c *LogClient) Close() error {
+	c.Stop()
+	if c.channel != nil {
+		_ = c.channel.Close()
+	}
+	if c.connection != nil {
+		_ = c.connection.Close()
+	}
+	return nil
+}
+
+// PublishAuditLog sends an audit log via the global client set up by
+// InitAuditLogClient/InitActivityLogClient. Prefer NewClient for new code.
+func PublishAuditLog(entry AuditLog) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishAuditLog(entry)
+}
+
+// PublishActivityLog sends an activity log via the global client. Prefer
+// NewClient for new code.
+func PublishActivityLog(entry ActivityLog) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishActivityLog(entry)
+}
+
+// PublishAuditLogWithKey sends an audit log via the global client. Prefer
+// NewClient for new code.
+func PublishAuditLogWithKey(entry AuditLog, routingKey string) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishAuditLogWithKey(entry, routingKey)
+}
+
+// PublishActivityLogWithKey sends an activity log via the global client.
+// Prefer NewClient for new code.
+func PublishActivityLogWithKey(entry ActivityLog, routingKey string) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
 	}
-	if logClient.connection != nil {
-		_ = logClient.connection.Close()
+	return c.PublishActivityLogWithKey(entry, routingKey)
+}
+
+// ConsumeAuditLogsWithTopics consumes from the global client. Prefer
+// NewClient for new code.
+func ConsumeAuditLogsWithTopics(topics []string, consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeAuditLogsWithTopics(topics, consumerName, handler, prefetchCount)
+}
+
+// ConsumeActivityLogsWithTopics consumes from the global client. Prefer
+// NewClient for new code.
+func ConsumeActivityLogsWithTopics(topics []string, consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
 	}
+	return c.ConsumeActivityLogsWithTopics(topics, consumerName, handler, prefetchCount)
+}
+
+// ConsumeAuditLogs consumes from the global client. Prefer NewClient for new code.
+func ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeAuditLogs(consumerName, handler, prefetchCount)
+}
+
+// ConsumeActivityLogs consumes from the global client. Prefer NewClient for new code.
+func ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeActivityLogs(consumerName, handler, prefetchCount)
+}
+
+// Close closes the channel and connection of the global LogClient. It is a
+// no-op if InitAuditLogClient/InitActivityLogClient was never called.
+func Close() {
+	if logClient == nil {
+		return
+	}
+	_ = logClient.Close()
 }
 
 // CloseGlobalClient closes the global log client.
 func CloseGlobalClient() {
 	Close()
-}
\ No newline at end of file
+}
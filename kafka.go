@@ -0,0 +1,183 @@
+package gologs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+
+	kafka "github.com/segmentio/kafka-go"
+)
+
+// kafkaTransport implements Transport on top of segmentio/kafka-go, one
+// writer per topic (AuditTopicName/ActivityTopicName). Readers are created
+// per ConsumeAuditLogs/ConsumeActivityLogs call and tracked so Close can stop
+// them instead of leaking their connection and fetch goroutine.
+type kafkaTransport struct {
+	brokers        []string
+	auditWriter    *kafka.Writer
+	activityWriter *kafka.Writer
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu              sync.Mutex
+	auditReaders    []*kafka.Reader
+	activityReaders []*kafka.Reader
+}
+
+// Kafka builds a Backend that publishes to and consumes from the
+// audit_logs/activity_logs topics on the given brokers.
+func Kafka(brokers []string) Backend {
+	return func() (Transport, error) {
+		ctx, cancel := context.WithCancel(context.Background())
+		return &kafkaTransport{
+			brokers: brokers,
+			auditWriter: &kafka.Writer{
+				Addr:     kafka.TCP(brokers...),
+				Topic:    AuditTopicName,
+				Balancer: &kafka.LeastBytes{},
+			},
+			activityWriter: &kafka.Writer{
+				Addr:     kafka.TCP(brokers...),
+				Topic:    ActivityTopicName,
+				Balancer: &kafka.LeastBytes{},
+			},
+			ctx:    ctx,
+			cancel: cancel,
+		}, nil
+	}
+}
+
+func (t *kafkaTransport) PublishAuditLog(entry AuditLog) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+	if err := t.auditWriter.WriteMessages(context.Background(), kafka.Message{Value: payload}); err != nil {
+		return fmt.Errorf("failed to publish audit message to kafka: %w", err)
+	}
+	return nil
+}
+
+func (t *kafkaTransport) PublishActivityLog(entry ActivityLog) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to marshal activity log: %w", err)
+	}
+	if err := t.activityWriter.WriteMessages(context.Background(), kafka.Message{Value: payload}); err != nil {
+		return fmt.Errorf("failed to publish activity message to kafka: %w", err)
+	}
+	return nil
+}
+
+// ConsumeAuditLogs reads AuditTopicName with a reader group named after
+// consumerName (or a default) and hands each message to handler. Kafka has
+// no per-message nack, so every Decision, Retry and Drop included, commits
+// the offset: a retried or dropped message is not redelivered, republished
+// or dead-lettered. The reader is tracked on kafkaTransport so Close can
+// stop it.
+func (t *kafkaTransport) ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	groupID := "default_audit_consumer"
+	if consumerName != nil {
+		groupID = *consumerName
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: t.brokers,
+		Topic:   AuditTopicName,
+		GroupID: groupID,
+	})
+
+	t.mu.Lock()
+	t.auditReaders = append(t.auditReaders, reader)
+	t.mu.Unlock()
+
+	go func() {
+		for {
+			msg, err := reader.FetchMessage(t.ctx)
+			if err != nil {
+				log.Printf("gologs: kafka audit reader stopped: %v", err)
+				return
+			}
+
+			var auditLog AuditLog
+			if err := json.Unmarshal(msg.Value, &auditLog); err != nil {
+				log.Printf("Error unmarshaling audit message: %v", err)
+				continue
+			}
+
+			handler(auditLog, func(decision Decision) {
+				if err := reader.CommitMessages(context.Background(), msg); err != nil {
+					log.Printf("gologs: failed to commit audit message offset: %v", err)
+				}
+			})
+		}
+	}()
+
+	log.Printf("Audit consumer %s is waiting for kafka messages. To exit press CTRL+C", groupID)
+	return nil
+}
+
+// ConsumeActivityLogs reads ActivityTopicName. See ConsumeAuditLogs for the
+// Decision/commit semantics and reader lifecycle.
+func (t *kafkaTransport) ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	groupID := "default_activity_consumer"
+	if consumerName != nil {
+		groupID = *consumerName
+	}
+
+	reader := kafka.NewReader(kafka.ReaderConfig{
+		Brokers: t.brokers,
+		Topic:   ActivityTopicName,
+		GroupID: groupID,
+	})
+
+	t.mu.Lock()
+	t.activityReaders = append(t.activityReaders, reader)
+	t.mu.Unlock()
+
+	go func() {
+		for {
+			msg, err := reader.FetchMessage(t.ctx)
+			if err != nil {
+				log.Printf("gologs: kafka activity reader stopped: %v", err)
+				return
+			}
+
+			var activityLog ActivityLog
+			if err := json.Unmarshal(msg.Value, &activityLog); err != nil {
+				log.Printf("Error unmarshaling activity message: %v", err)
+				continue
+			}
+
+			handler(activityLog, func(decision Decision) {
+				if err := reader.CommitMessages(context.Background(), msg); err != nil {
+					log.Printf("gologs: failed to commit activity message offset: %v", err)
+				}
+			})
+		}
+	}()
+
+	log.Printf("Activity consumer %s is waiting for kafka messages. To exit press CTRL+C", groupID)
+	return nil
+}
+
+// Close cancels every reader's FetchMessage via t.ctx, closes the writers,
+// and closes each tracked reader.
+func (t *kafkaTransport) Close() error {
+	t.cancel()
+	_ = t.auditWriter.Close()
+	_ = t.activityWriter.Close()
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, reader := range t.auditReaders {
+		_ = reader.Close()
+	}
+	for _, reader := range t.activityReaders {
+		_ = reader.Close()
+	}
+	return nil
+}
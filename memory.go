@@ -0,0 +1,75 @@
+package gologs
+
+import (
+	"sync"
+	"time"
+)
+
+// inMemoryTransport implements Transport without a broker, for tests that
+// exercise code built on NewClient/LogPublisher/LogConsumer without a real
+// RabbitMQ or Kafka instance. Decisions are accepted but not acted on: there
+// is no queue to ack, retry or dead-letter against.
+type inMemoryTransport struct {
+	mu               sync.Mutex
+	auditHandlers    []func(AuditLog, func(Decision))
+	activityHandlers []func(ActivityLog, func(Decision))
+}
+
+// InMemory builds a Backend that delivers published logs directly to any
+// handlers registered via ConsumeAuditLogs/ConsumeActivityLogs in the same
+// process, with no broker involved.
+func InMemory() Backend {
+	return func() (Transport, error) {
+		return &inMemoryTransport{}, nil
+	}
+}
+
+func (t *inMemoryTransport) PublishAuditLog(entry AuditLog) error {
+	entry.ActionTime = time.Now()
+
+	t.mu.Lock()
+	var handlers []func(AuditLog, func(Decision))
+	handlers = append(handlers, t.auditHandlers...)
+	t.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(entry, func(Decision) {})
+	}
+	return nil
+}
+
+func (t *inMemoryTransport) PublishActivityLog(entry ActivityLog) error {
+	entry.ActivityTime = time.Now()
+
+	t.mu.Lock()
+	var handlers []func(ActivityLog, func(Decision))
+	handlers = append(handlers, t.activityHandlers...)
+	t.mu.Unlock()
+
+	for _, handler := range handlers {
+		handler(entry, func(Decision) {})
+	}
+	return nil
+}
+
+func (t *inMemoryTransport) ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	t.mu.Lock()
+	t.auditHandlers = append(t.auditHandlers, handler)
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *inMemoryTransport) ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	t.mu.Lock()
+	t.activityHandlers = append(t.activityHandlers, handler)
+	t.mu.Unlock()
+	return nil
+}
+
+func (t *inMemoryTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.auditHandlers = nil
+	t.activityHandlers = nil
+	return nil
+}
@@ -0,0 +1,85 @@
+package gologs
+
+import "testing"
+
+func TestInMemoryTransportAuditRoundTrip(t *testing.T) {
+	transport, err := InMemory()()
+	if err != nil {
+		t.Fatalf("InMemory() returned error: %v", err)
+	}
+	defer transport.Close()
+
+	received := make(chan AuditLog, 1)
+	if err := transport.ConsumeAuditLogs(nil, func(entry AuditLog, decide func(Decision)) {
+		received <- entry
+		decide(Ack)
+	}, nil); err != nil {
+		t.Fatalf("ConsumeAuditLogs returned error: %v", err)
+	}
+
+	want := AuditLog{Module: "users", ActionType: "create", ActionBy: "tester"}
+	if err := transport.PublishAuditLog(want); err != nil {
+		t.Fatalf("PublishAuditLog returned error: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		if got.Module != want.Module || got.ActionType != want.ActionType || got.ActionBy != want.ActionBy {
+			t.Fatalf("got %+v, want %+v", got, want)
+		}
+	default:
+		t.Fatal("handler was not invoked synchronously by PublishAuditLog")
+	}
+}
+
+func TestInMemoryTransportActivityDecision(t *testing.T) {
+	transport, err := InMemory()()
+	if err != nil {
+		t.Fatalf("InMemory() returned error: %v", err)
+	}
+	defer transport.Close()
+
+	var gotDecision Decision = -1
+	if err := transport.ConsumeActivityLogs(nil, func(entry ActivityLog, decide func(Decision)) {
+		decide(Retry)
+		gotDecision = Retry
+	}, nil); err != nil {
+		t.Fatalf("ConsumeActivityLogs returned error: %v", err)
+	}
+
+	if err := transport.PublishActivityLog(ActivityLog{UserID: "u1", Activity: "login"}); err != nil {
+		t.Fatalf("PublishActivityLog returned error: %v", err)
+	}
+
+	// The in-memory transport ignores decisions, so this only checks that
+	// the handler ran and that calling decide(Retry) returned.
+	if gotDecision != Retry {
+		t.Fatalf("handler did not run to completion after decide(Retry), got %v", gotDecision)
+	}
+}
+
+func TestInMemoryTransportCloseStopsDelivery(t *testing.T) {
+	transport, err := InMemory()()
+	if err != nil {
+		t.Fatalf("InMemory() returned error: %v", err)
+	}
+
+	delivered := false
+	if err := transport.ConsumeAuditLogs(nil, func(entry AuditLog, decide func(Decision)) {
+		delivered = true
+	}, nil); err != nil {
+		t.Fatalf("ConsumeAuditLogs returned error: %v", err)
+	}
+
+	if err := transport.Close(); err != nil {
+		t.Fatalf("Close returned error: %v", err)
+	}
+
+	if err := transport.PublishAuditLog(AuditLog{Module: "users"}); err != nil {
+		t.Fatalf("PublishAuditLog returned error: %v", err)
+	}
+
+	if delivered {
+		t.Fatal("handler ran after Close; Close should drop registered handlers")
+	}
+}
@@ -0,0 +1,233 @@
+package gologs
+
+import (
+	"errors"
+	"log"
+	"math/rand"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+// ErrDisconnected is returned by publish calls made while the client is
+// reconnecting to the broker.
+var ErrDisconnected = errors.New("gologs: client is disconnected, reconnecting")
+
+// ReconnectOptions configures automatic reconnection for a LogClient created
+// via NewLogClient(..., WithReconnect(...)).
+type ReconnectOptions struct {
+	// MinBackoff is the delay before the first reconnect attempt. Defaults to 1s.
+	MinBackoff time.Duration
+	// MaxBackoff caps the exponential backoff between attempts. Defaults to 30s.
+	MaxBackoff time.Duration
+	// BufferSize, if greater than zero, lets PublishAuditLog/PublishActivityLog
+	// (and their WithKey variants) queue publishes in memory while
+	// disconnected instead of failing with ErrDisconnected; buffered
+	// publishes are replayed in order once the channel is re-established.
+	BufferSize int
+}
+
+// Option configures a LogClient created by NewLogClient.
+type Option func(*LogClient)
+
+// WithReconnect enables connection/channel supervision: on connection or
+// channel loss the client retries amqp.Dial with jittered exponential
+// backoff between opts.MinBackoff and opts.MaxBackoff, redeclares its queue,
+// and re-registers any active consumers under their original name, prefetch
+// and handler.
+func WithReconnect(opts ReconnectOptions) Option {
+	if opts.MinBackoff <= 0 {
+		opts.MinBackoff = time.Second
+	}
+	if opts.MaxBackoff <= 0 {
+		opts.MaxBackoff = 30 * time.Second
+	}
+	return func(c *LogClient) {
+		c.reconnect = &opts
+		if opts.BufferSize > 0 {
+			c.pending = make(chan pendingPublish, opts.BufferSize)
+		}
+	}
+}
+
+// registeredConsumer lets the supervisor goroutine re-establish a consumer
+// with its original name, prefetch count, topic bindings and handler after a
+// reconnect.
+type registeredConsumer struct {
+	consumerName string
+	start        func() error
+}
+
+// pendingPublish is a publish that was buffered while disconnected, to be
+// replayed once the channel is re-established.
+type pendingPublish struct {
+	exchange   string
+	routingKey string
+	publishing amqp.Publishing
+}
+
+// NewLogClient connects to RABBITMQ_URL and declares topicName, optionally
+// supervising the connection with WithReconnect. Unlike InitAuditLogClient/
+// InitActivityLogClient, it does not touch the package's global client;
+// callers who want the legacy PublishAuditLog/ConsumeAuditLogs family to
+// work should use InitAuditLogClient/InitActivityLogClient instead.
+func NewLogClient(topicName string, opts ...Option) (*LogClient, error) {
+	c := &LogClient{
+		topicName:  topicName,
+		closing:    make(chan struct{}),
+		maxRetries: DefaultMaxRetries,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	if err := c.dial(); err != nil {
+		return nil, err
+	}
+
+	if c.reconnect != nil {
+		go c.supervise()
+	}
+
+	return c, nil
+}
+
+// activeChannel returns the channel to publish or consume on. If the client
+// is mid-reconnect, it returns ErrDisconnected rather than a channel that is
+// about to be closed.
+func (c *LogClient) activeChannel() (*amqp.Channel, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.connected {
+		return nil, ErrDisconnected
+	}
+	return c.channel, nil
+}
+
+// registerConsumer records how to re-establish a consumer after a reconnect.
+// It is a no-op unless the client was created with WithReconnect.
+func (c *LogClient) registerConsumer(consumerName string, start func() error) {
+	if c.reconnect == nil {
+		return
+	}
+	c.mu.Lock()
+	c.consumers = append(c.consumers, registeredConsumer{consumerName: consumerName, start: start})
+	c.mu.Unlock()
+}
+
+// bufferPublish queues a publish for replay once reconnected. It returns
+// false if buffering isn't enabled or the buffer is full.
+func (c *LogClient) bufferPublish(exchange, routingKey string, publishing amqp.Publishing) bool {
+	if c.pending == nil {
+		return false
+	}
+	select {
+	case c.pending <- pendingPublish{exchange: exchange, routingKey: routingKey, publishing: publishing}:
+		return true
+	default:
+		return false
+	}
+}
+
+// supervise watches the current connection and channel for closure and
+// drives reconnection. It runs for the lifetime of the client.
+func (c *LogClient) supervise() {
+	for {
+		c.mu.Lock()
+		conn, ch := c.connection, c.channel
+		c.mu.Unlock()
+
+		connClosed := conn.NotifyClose(make(chan *amqp.Error, 1))
+		chClosed := ch.NotifyClose(make(chan *amqp.Error, 1))
+
+		select {
+		case <-c.closing:
+			return
+		case err := <-connClosed:
+			log.Printf("gologs: connection to RabbitMQ closed: %v", err)
+		case err := <-chClosed:
+			log.Printf("gologs: RabbitMQ channel closed: %v", err)
+		}
+
+		c.mu.Lock()
+		c.connected = false
+		c.mu.Unlock()
+
+		if !c.reconnectLoop() {
+			return
+		}
+	}
+}
+
+// reconnectLoop redials with jittered exponential backoff until it succeeds
+// or the client is closed, then re-declares bindings, re-registers
+// consumers and flushes any buffered publishes. It reports whether the
+// supervisor should keep watching the new connection.
+func (c *LogClient) reconnectLoop() bool {
+	backoff := c.reconnect.MinBackoff
+
+	for {
+		select {
+		case <-c.closing:
+			return false
+		case <-time.After(backoff):
+		}
+
+		if err := c.dial(); err != nil {
+			backoff *= 2
+			backoff += time.Duration(rand.Int63n(int64(backoff)/2 + 1))
+			if backoff > c.reconnect.MaxBackoff {
+				backoff = c.reconnect.MaxBackoff
+			}
+			log.Printf("gologs: reconnect attempt failed, retrying in %s: %v", backoff, err)
+			continue
+		}
+
+		c.mu.Lock()
+		consumers := append([]registeredConsumer(nil), c.consumers...)
+		c.consumers = nil
+		c.mu.Unlock()
+
+		for _, rc := range consumers {
+			if err := rc.start(); err != nil {
+				log.Printf("gologs: failed to re-register consumer %s after reconnect: %v", rc.consumerName, err)
+			}
+		}
+
+		c.flushPending()
+		log.Printf("gologs: reconnected to RabbitMQ")
+		return true
+	}
+}
+
+// flushPending replays any publishes buffered while disconnected, in order.
+func (c *LogClient) flushPending() {
+	if c.pending == nil {
+		return
+	}
+	for {
+		select {
+		case p := <-c.pending:
+			ch, err := c.activeChannel()
+			if err != nil {
+				log.Printf("gologs: dropping buffered publish to %q, still disconnected", p.exchange)
+				continue
+			}
+			if err := ch.Publish(p.exchange, p.routingKey, false, false, p.publishing); err != nil {
+				log.Printf("gologs: failed to replay buffered publish to %q: %v", p.exchange, err)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// Stop halts the reconnect supervisor, if any. Close still closes the
+// underlying channel/connection.
+func (c *LogClient) Stop() {
+	select {
+	case <-c.closing:
+	default:
+		close(c.closing)
+	}
+}
@@ -0,0 +1,340 @@
+package gologs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName identifies gologs' spans among others sharing a TracerProvider.
+const tracerName = "gologs"
+
+// WithTracerProvider wires tp into the client's *Context publish/consume
+// variants instead of the global otel.GetTracerProvider(). gologs never
+// imports a specific exporter; callers configure and pass their own tp.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *LogClient) {
+		c.tracerProvider = tp
+	}
+}
+
+// tracer returns the client's configured tracer, falling back to the global
+// TracerProvider if WithTracerProvider wasn't used.
+func (c *LogClient) tracer() trace.Tracer {
+	tp := c.tracerProvider
+	if tp == nil {
+		tp = otel.GetTracerProvider()
+	}
+	return tp.Tracer(tracerName)
+}
+
+// propagator returns the propagator used to inject/extract trace context
+// to/from AMQP headers: W3C traceparent plus baggage. This is independent
+// of whatever (if anything) otel.SetTextMapPropagator has set globally, so
+// trace propagation across the queue works even if the process never
+// configured one.
+func (c *LogClient) propagator() propagation.TextMapPropagator {
+	return propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+}
+
+// amqpHeaderCarrier adapts amqp.Table to propagation.TextMapCarrier so trace
+// context can be injected into, and extracted from, message headers.
+type amqpHeaderCarrier amqp.Table
+
+func (c amqpHeaderCarrier) Get(key string) string {
+	v, ok := c[key]
+	if !ok {
+		return ""
+	}
+	s, _ := v.(string)
+	return s
+}
+
+func (c amqpHeaderCarrier) Set(key, value string) {
+	c[key] = value
+}
+
+func (c amqpHeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for k := range c {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// PublishAuditLogContext is PublishAuditLog with trace propagation: it opens
+// a "gologs.publish audit_logs" producer span and injects ctx's traceparent
+// and baggage into the message headers, so ConsumeAuditLogsContext on the
+// receiving end continues the same trace.
+func (c *LogClient) PublishAuditLogContext(ctx context.Context, entry AuditLog) error {
+	ctx, span := c.tracer().Start(ctx, "gologs.publish "+AuditTopicName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination", AuditTopicName),
+		),
+	)
+	defer span.End()
+
+	entry.ActionTime = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal audit log: %w", err)
+	}
+
+	headers := amqp.Table{}
+	c.propagator().Inject(ctx, amqpHeaderCarrier(headers))
+
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Headers:     headers,
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish("", AuditTopicName, publishing) {
+			return nil
+		}
+		span.RecordError(err)
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, "", AuditTopicName, false, publishing); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to publish audit message: %w", err)
+	}
+
+	return nil
+}
+
+// PublishActivityLogContext is PublishActivityLog with trace propagation.
+// See PublishAuditLogContext.
+func (c *LogClient) PublishActivityLogContext(ctx context.Context, entry ActivityLog) error {
+	ctx, span := c.tracer().Start(ctx, "gologs.publish "+ActivityTopicName,
+		trace.WithSpanKind(trace.SpanKindProducer),
+		trace.WithAttributes(
+			attribute.String("messaging.system", "rabbitmq"),
+			attribute.String("messaging.destination", ActivityTopicName),
+		),
+	)
+	defer span.End()
+
+	entry.ActivityTime = time.Now()
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to marshal activity log: %w", err)
+	}
+
+	headers := amqp.Table{}
+	c.propagator().Inject(ctx, amqpHeaderCarrier(headers))
+
+	publishing := amqp.Publishing{
+		ContentType: "application/json",
+		Headers:     headers,
+		Body:        payload,
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		if c.bufferPublish("", ActivityTopicName, publishing) {
+			return nil
+		}
+		span.RecordError(err)
+		return err
+	}
+
+	if err := c.publishWithConfirm(ch, "", ActivityTopicName, false, publishing); err != nil {
+		span.RecordError(err)
+		return fmt.Errorf("failed to publish activity message: %w", err)
+	}
+
+	return nil
+}
+
+// ConsumeAuditLogsContext is ConsumeAuditLogs with trace propagation: for
+// each delivery it extracts the producer's traceparent/baggage (if any)
+// from the message headers into a new context, opens a
+// "gologs.consume audit_logs" consumer span as its child, and passes that
+// context to handler as its first argument.
+func (c *LogClient) ConsumeAuditLogsContext(consumerName *string, handler func(context.Context, AuditLog, func(Decision)), prefetchCount *int) error {
+	if consumerName == nil {
+		defaultName := "default_audit_consumer"
+		consumerName = &defaultName
+	}
+
+	var effectivePrefetchCount int
+	if prefetchCount != nil {
+		effectivePrefetchCount = *prefetchCount
+	} else {
+		effectivePrefetchCount = 50 // Default value
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		AuditTopicName, // queue
+		*consumerName,  // consumer name
+		false,          // auto-ack
+		false,          // exclusive
+		false,          // no-local
+		false,          // no-wait
+		nil,            // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register an audit consumer: %w", err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			var auditLog AuditLog
+			if err := json.Unmarshal(msg.Body, &auditLog); err != nil {
+				log.Printf("Error unmarshaling audit message: %v", err)
+				continue
+			}
+
+			ctx := c.propagator().Extract(context.Background(), amqpHeaderCarrier(msg.Headers))
+			ctx, span := c.tracer().Start(ctx, "gologs.consume "+AuditTopicName,
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("messaging.system", "rabbitmq"),
+					attribute.String("messaging.destination", AuditTopicName),
+				),
+			)
+
+			handler(ctx, auditLog, func(decision Decision) {
+				resolveDecision(ch, msg, AuditTopicName, c.maxRetries, decision)
+			})
+			span.End()
+		}
+	}()
+
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeAuditLogsContext(consumerName, handler, prefetchCount)
+	})
+
+	log.Printf("Audit consumer %s is waiting for messages. To exit press CTRL+C", *consumerName)
+	return nil
+}
+
+// ConsumeActivityLogsContext is ConsumeActivityLogs with trace propagation.
+// See ConsumeAuditLogsContext.
+func (c *LogClient) ConsumeActivityLogsContext(consumerName *string, handler func(context.Context, ActivityLog, func(Decision)), prefetchCount *int) error {
+	if consumerName == nil {
+		defaultName := "default_activity_consumer"
+		consumerName = &defaultName
+	}
+
+	var effectivePrefetchCount int
+	if prefetchCount != nil {
+		effectivePrefetchCount = *prefetchCount
+	} else {
+		effectivePrefetchCount = 50 // Default value
+	}
+
+	ch, err := c.activeChannel()
+	if err != nil {
+		return err
+	}
+
+	if err := ch.Qos(effectivePrefetchCount, 0, false); err != nil {
+		return fmt.Errorf("failed to set QoS: %w", err)
+	}
+
+	msgs, err := ch.Consume(
+		ActivityTopicName, // queue
+		*consumerName,     // consumer name
+		false,             // auto-ack
+		false,             // exclusive
+		false,             // no-local
+		false,             // no-wait
+		nil,               // args
+	)
+	if err != nil {
+		return fmt.Errorf("failed to register an activity consumer: %w", err)
+	}
+
+	go func() {
+		for msg := range msgs {
+			var activityLog ActivityLog
+			if err := json.Unmarshal(msg.Body, &activityLog); err != nil {
+				log.Printf("Error unmarshaling activity message: %v", err)
+				continue
+			}
+
+			ctx := c.propagator().Extract(context.Background(), amqpHeaderCarrier(msg.Headers))
+			ctx, span := c.tracer().Start(ctx, "gologs.consume "+ActivityTopicName,
+				trace.WithSpanKind(trace.SpanKindConsumer),
+				trace.WithAttributes(
+					attribute.String("messaging.system", "rabbitmq"),
+					attribute.String("messaging.destination", ActivityTopicName),
+				),
+			)
+
+			handler(ctx, activityLog, func(decision Decision) {
+				resolveDecision(ch, msg, ActivityTopicName, c.maxRetries, decision)
+			})
+			span.End()
+		}
+	}()
+
+	c.registerConsumer(*consumerName, func() error {
+		return c.ConsumeActivityLogsContext(consumerName, handler, prefetchCount)
+	})
+
+	log.Printf("Activity consumer %s is waiting for messages. To exit press CTRL+C", *consumerName)
+	return nil
+}
+
+// PublishAuditLogContext publishes via the global client. Prefer NewClient for new code.
+func PublishAuditLogContext(ctx context.Context, entry AuditLog) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishAuditLogContext(ctx, entry)
+}
+
+// PublishActivityLogContext publishes via the global client. Prefer NewClient for new code.
+func PublishActivityLogContext(ctx context.Context, entry ActivityLog) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.PublishActivityLogContext(ctx, entry)
+}
+
+// ConsumeAuditLogsContext consumes from the global client. Prefer NewClient for new code.
+func ConsumeAuditLogsContext(consumerName *string, handler func(context.Context, AuditLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeAuditLogsContext(consumerName, handler, prefetchCount)
+}
+
+// ConsumeActivityLogsContext consumes from the global client. Prefer NewClient for new code.
+func ConsumeActivityLogsContext(consumerName *string, handler func(context.Context, ActivityLog, func(Decision)), prefetchCount *int) error {
+	c, err := globalClient()
+	if err != nil {
+		return err
+	}
+	return c.ConsumeActivityLogsContext(consumerName, handler, prefetchCount)
+}
@@ -0,0 +1,205 @@
+package gologs
+
+import "context"
+
+// LogPublisher sends audit and activity log entries to a backend.
+type LogPublisher interface {
+	PublishAuditLog(entry AuditLog) error
+	PublishActivityLog(entry ActivityLog) error
+}
+
+// LogConsumer subscribes to audit and activity log entries from a backend.
+type LogConsumer interface {
+	ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error
+	ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error
+}
+
+// Transport is a complete backend for the audit/activity log APIs: a
+// LogPublisher, a LogConsumer, and something that can be shut down cleanly.
+type Transport interface {
+	LogPublisher
+	LogConsumer
+	Close() error
+}
+
+// TopicPublisher is implemented by transports that support routing-key based
+// topic delivery, e.g. the RabbitMQ backend's PublishAuditLogWithKey. Not
+// every Backend implements it; type-assert Client.Transport to use it.
+type TopicPublisher interface {
+	PublishAuditLogWithKey(entry AuditLog, routingKey string) error
+	PublishActivityLogWithKey(entry ActivityLog, routingKey string) error
+}
+
+// TopicConsumer is implemented by transports that support subscribing by
+// binding pattern rather than reading the whole queue. Type-assert
+// Client.Transport to use it.
+type TopicConsumer interface {
+	ConsumeAuditLogsWithTopics(topics []string, consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error
+	ConsumeActivityLogsWithTopics(topics []string, consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error
+}
+
+// SyncPublisher is implemented by transports that can publish and block for
+// broker acknowledgement. Type-assert Client.Transport to use it.
+type SyncPublisher interface {
+	PublishAuditLogSync(ctx context.Context, entry AuditLog, opts *PublishOptions) error
+}
+
+// DeadLetterConsumer is implemented by transports with a dead-letter queue to
+// inspect or replay. Type-assert Client.Transport to use it.
+type DeadLetterConsumer interface {
+	ConsumeAuditDeadLetters(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error
+	ConsumeActivityDeadLetters(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error
+}
+
+// ContextPublisher is implemented by transports that propagate trace context
+// on publish. Type-assert Client.Transport to use it.
+type ContextPublisher interface {
+	PublishAuditLogContext(ctx context.Context, entry AuditLog) error
+	PublishActivityLogContext(ctx context.Context, entry ActivityLog) error
+}
+
+// ContextConsumer is implemented by transports that extract trace context on
+// consume and pass it to the handler. Type-assert Client.Transport to use it.
+type ContextConsumer interface {
+	ConsumeAuditLogsContext(consumerName *string, handler func(context.Context, AuditLog, func(Decision)), prefetchCount *int) error
+	ConsumeActivityLogsContext(consumerName *string, handler func(context.Context, ActivityLog, func(Decision)), prefetchCount *int) error
+}
+
+// Backend builds a Transport. NewClient uses it to assemble a Client without
+// hard-coding any particular transport, e.g. gologs.RabbitMQ(url) or
+// gologs.Kafka(brokers).
+type Backend func() (Transport, error)
+
+// Client is a transport-agnostic handle on the audit/activity log APIs. It
+// does not rely on global state, unlike InitAuditLogClient/PublishAuditLog/...,
+// so a process can hold several independently configured Clients. Features
+// not every Backend supports (topic routing, publisher confirms, dead
+// letters, trace propagation) aren't part of Transport itself; type-assert
+// Client.Transport against TopicPublisher, TopicConsumer, SyncPublisher,
+// DeadLetterConsumer, ContextPublisher or ContextConsumer to reach them when
+// the backend in use supports them, e.g. RabbitMQ does for all six.
+type Client struct {
+	Transport
+}
+
+// NewClient builds a Client from backend, e.g.:
+//
+//	client, err := gologs.NewClient(gologs.RabbitMQ(url))
+//	client, err := gologs.NewClient(gologs.Kafka(brokers))
+//	client, err := gologs.NewClient(gologs.InMemory()) // for tests
+func NewClient(backend Backend) (*Client, error) {
+	transport, err := backend()
+	if err != nil {
+		return nil, err
+	}
+	return &Client{Transport: transport}, nil
+}
+
+// withURL pins the RabbitMQ URL a LogClient dials, bypassing the
+// RABBITMQ_URL environment lookup InitAuditLogClient/InitActivityLogClient
+// rely on. It must be applied before any other Option.
+func withURL(url string) Option {
+	return func(c *LogClient) {
+		c.url = url
+	}
+}
+
+// rabbitMQTransport implements Transport on top of two LogClients, one per
+// queue, since a single LogClient only declares the queue it was built for.
+type rabbitMQTransport struct {
+	audit    *LogClient
+	activity *LogClient
+}
+
+// RabbitMQ builds a Backend that dials url and declares both the audit_logs
+// and activity_logs queues. opts is applied to both underlying LogClients,
+// so e.g. gologs.RabbitMQ(url, gologs.WithReconnect(...)) supervises both.
+func RabbitMQ(url string, opts ...Option) Backend {
+	return func() (Transport, error) {
+		allOpts := append([]Option{withURL(url)}, opts...)
+
+		audit, err := NewLogClient(AuditTopicName, allOpts...)
+		if err != nil {
+			return nil, err
+		}
+
+		activity, err := NewLogClient(ActivityTopicName, allOpts...)
+		if err != nil {
+			_ = audit.Close()
+			return nil, err
+		}
+
+		return &rabbitMQTransport{audit: audit, activity: activity}, nil
+	}
+}
+
+func (t *rabbitMQTransport) PublishAuditLog(entry AuditLog) error {
+	return t.audit.PublishAuditLog(entry)
+}
+
+func (t *rabbitMQTransport) PublishActivityLog(entry ActivityLog) error {
+	return t.activity.PublishActivityLog(entry)
+}
+
+func (t *rabbitMQTransport) ConsumeAuditLogs(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	return t.audit.ConsumeAuditLogs(consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) ConsumeActivityLogs(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	return t.activity.ConsumeActivityLogs(consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) Close() error {
+	_ = t.audit.Close()
+	_ = t.activity.Close()
+	return nil
+}
+
+// The methods below make rabbitMQTransport additionally satisfy
+// TopicPublisher, TopicConsumer, SyncPublisher, DeadLetterConsumer,
+// ContextPublisher and ContextConsumer, so a caller holding a Client built
+// from RabbitMQ(...) can type-assert Client.Transport to reach them.
+
+func (t *rabbitMQTransport) PublishAuditLogWithKey(entry AuditLog, routingKey string) error {
+	return t.audit.PublishAuditLogWithKey(entry, routingKey)
+}
+
+func (t *rabbitMQTransport) PublishActivityLogWithKey(entry ActivityLog, routingKey string) error {
+	return t.activity.PublishActivityLogWithKey(entry, routingKey)
+}
+
+func (t *rabbitMQTransport) ConsumeAuditLogsWithTopics(topics []string, consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	return t.audit.ConsumeAuditLogsWithTopics(topics, consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) ConsumeActivityLogsWithTopics(topics []string, consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	return t.activity.ConsumeActivityLogsWithTopics(topics, consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) PublishAuditLogSync(ctx context.Context, entry AuditLog, opts *PublishOptions) error {
+	return t.audit.PublishAuditLogSync(ctx, entry, opts)
+}
+
+func (t *rabbitMQTransport) ConsumeAuditDeadLetters(consumerName *string, handler func(AuditLog, func(Decision)), prefetchCount *int) error {
+	return t.audit.ConsumeAuditDeadLetters(consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) ConsumeActivityDeadLetters(consumerName *string, handler func(ActivityLog, func(Decision)), prefetchCount *int) error {
+	return t.activity.ConsumeActivityDeadLetters(consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) PublishAuditLogContext(ctx context.Context, entry AuditLog) error {
+	return t.audit.PublishAuditLogContext(ctx, entry)
+}
+
+func (t *rabbitMQTransport) PublishActivityLogContext(ctx context.Context, entry ActivityLog) error {
+	return t.activity.PublishActivityLogContext(ctx, entry)
+}
+
+func (t *rabbitMQTransport) ConsumeAuditLogsContext(consumerName *string, handler func(context.Context, AuditLog, func(Decision)), prefetchCount *int) error {
+	return t.audit.ConsumeAuditLogsContext(consumerName, handler, prefetchCount)
+}
+
+func (t *rabbitMQTransport) ConsumeActivityLogsContext(consumerName *string, handler func(context.Context, ActivityLog, func(Decision)), prefetchCount *int) error {
+	return t.activity.ConsumeActivityLogsContext(consumerName, handler, prefetchCount)
+}